@@ -0,0 +1,255 @@
+// ==================================================================================
+// File: router.proto
+//
+// Desc: Router gRPC service definition. Mirrors the streaming patterns used by
+//       container-registry content APIs: a unary call for a single lookup, a
+//       bidirectional stream for pipelined batch lookups over one connection, a
+//       client stream for ingesting route costs without touching disk on the
+//       server, and a server stream for progress updates on long ingests.
+//
+// Copyright © 2019 Edwin Cloud. All rights reserved.
+// ==================================================================================
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: api/v1/router.proto
+
+package routerv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Router_Lookup_FullMethodName       = "/router.v1.Router/Lookup"
+	Router_LookupStream_FullMethodName = "/router.v1.Router/LookupStream"
+	Router_LoadRoutes_FullMethodName   = "/router.v1.Router/LoadRoutes"
+	Router_Status_FullMethodName       = "/router.v1.Router/Status"
+)
+
+// RouterClient is the client API for Router service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Router looks up per-prefix, per-carrier route costs and loads route-cost
+// data into the server's in-memory trie.
+type RouterClient interface {
+	// Lookup returns the cost for a single number.
+	Lookup(ctx context.Context, in *NumberRequest, opts ...grpc.CallOption) (*CostResponse, error)
+	// LookupStream pipelines many number lookups over a single connection.
+	LookupStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NumberRequest, CostResponse], error)
+	// LoadRoutes streams a route-cost CSV into the server's trie without the
+	// server ever reading it from disk.
+	LoadRoutes(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RouteChunk, LoadStatus], error)
+	// Status periodically emits ingest progress while a LoadRoutes call is
+	// in flight.
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusResponse], error)
+}
+
+type routerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRouterClient(cc grpc.ClientConnInterface) RouterClient {
+	return &routerClient{cc}
+}
+
+func (c *routerClient) Lookup(ctx context.Context, in *NumberRequest, opts ...grpc.CallOption) (*CostResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CostResponse)
+	err := c.cc.Invoke(ctx, Router_Lookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerClient) LookupStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[NumberRequest, CostResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[0], Router_LookupStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[NumberRequest, CostResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Router_LookupStreamClient = grpc.BidiStreamingClient[NumberRequest, CostResponse]
+
+func (c *routerClient) LoadRoutes(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[RouteChunk, LoadStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[1], Router_LoadRoutes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RouteChunk, LoadStatus]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Router_LoadRoutesClient = grpc.ClientStreamingClient[RouteChunk, LoadStatus]
+
+func (c *routerClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StatusResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Router_ServiceDesc.Streams[2], Router_Status_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[emptypb.Empty, StatusResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Router_StatusClient = grpc.ServerStreamingClient[StatusResponse]
+
+// RouterServer is the server API for Router service.
+// All implementations should embed UnimplementedRouterServer
+// for forward compatibility.
+//
+// Router looks up per-prefix, per-carrier route costs and loads route-cost
+// data into the server's in-memory trie.
+type RouterServer interface {
+	// Lookup returns the cost for a single number.
+	Lookup(context.Context, *NumberRequest) (*CostResponse, error)
+	// LookupStream pipelines many number lookups over a single connection.
+	LookupStream(grpc.BidiStreamingServer[NumberRequest, CostResponse]) error
+	// LoadRoutes streams a route-cost CSV into the server's trie without the
+	// server ever reading it from disk.
+	LoadRoutes(grpc.ClientStreamingServer[RouteChunk, LoadStatus]) error
+	// Status periodically emits ingest progress while a LoadRoutes call is
+	// in flight.
+	Status(*emptypb.Empty, grpc.ServerStreamingServer[StatusResponse]) error
+}
+
+// UnimplementedRouterServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRouterServer struct{}
+
+func (UnimplementedRouterServer) Lookup(context.Context, *NumberRequest) (*CostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lookup not implemented")
+}
+func (UnimplementedRouterServer) LookupStream(grpc.BidiStreamingServer[NumberRequest, CostResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method LookupStream not implemented")
+}
+func (UnimplementedRouterServer) LoadRoutes(grpc.ClientStreamingServer[RouteChunk, LoadStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method LoadRoutes not implemented")
+}
+func (UnimplementedRouterServer) Status(*emptypb.Empty, grpc.ServerStreamingServer[StatusResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (UnimplementedRouterServer) testEmbeddedByValue() {}
+
+// UnsafeRouterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RouterServer will
+// result in compilation errors.
+type UnsafeRouterServer interface {
+	mustEmbedUnimplementedRouterServer()
+}
+
+func RegisterRouterServer(s grpc.ServiceRegistrar, srv RouterServer) {
+	// If the following call pancis, it indicates UnimplementedRouterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Router_ServiceDesc, srv)
+}
+
+func _Router_Lookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterServer).Lookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Router_Lookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterServer).Lookup(ctx, req.(*NumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Router_LookupStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RouterServer).LookupStream(&grpc.GenericServerStream[NumberRequest, CostResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Router_LookupStreamServer = grpc.BidiStreamingServer[NumberRequest, CostResponse]
+
+func _Router_LoadRoutes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RouterServer).LoadRoutes(&grpc.GenericServerStream[RouteChunk, LoadStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Router_LoadRoutesServer = grpc.ClientStreamingServer[RouteChunk, LoadStatus]
+
+func _Router_Status_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(emptypb.Empty)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouterServer).Status(m, &grpc.GenericServerStream[emptypb.Empty, StatusResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Router_StatusServer = grpc.ServerStreamingServer[StatusResponse]
+
+// Router_ServiceDesc is the grpc.ServiceDesc for Router service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Router_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "router.v1.Router",
+	HandlerType: (*RouterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Lookup",
+			Handler:    _Router_Lookup_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "LookupStream",
+			Handler:       _Router_LookupStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "LoadRoutes",
+			Handler:       _Router_LoadRoutes_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Status",
+			Handler:       _Router_Status_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/v1/router.proto",
+}
@@ -0,0 +1,77 @@
+// ==================================================================================
+// File: main.go
+//
+// Desc: routesvc serves the Router gRPC service defined in api/v1/router.proto.
+//       It holds the route-cost trie in memory and is the only process that
+//       touches route-cost files on disk or accepts LoadRoutes streams; the
+//       interactive TUI in the repo root is a thin client of this service.
+//
+// Copyright © 2019 Edwin Cloud. All rights reserved.
+// ==================================================================================
+package main
+
+// ----------------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------------
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"path/filepath"
+
+	routerv1 "github.com/edwintcloud/Call-Routing-Project/go_solution/api/v1"
+	"github.com/edwintcloud/Call-Routing-Project/go_solution/pkg/router"
+	"google.golang.org/grpc"
+)
+
+// ----------------------------------------------------------------------------------
+// Global Functions
+// ----------------------------------------------------------------------------------
+
+// main is the main entry point of routesvc.
+func main() {
+	addr := flag.String("addr", ":50051", "address for the Router gRPC service to listen on")
+	preloadDir := flag.String("preload-dir", "", "directory of rate-sheet CSVs to load into the trie at startup, one carrier per file, via Router.LoadFiles")
+	flag.Parse()
+
+	r := router.New()
+	if *preloadDir != "" {
+		if err := preload(r, *preloadDir); err != nil {
+			log.Fatalf("failed to preload %s: %v", *preloadDir, err)
+		}
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", *addr, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	routerv1.RegisterRouterServer(grpcServer, router.NewServer(r))
+
+	log.Printf("routesvc listening on %s", *addr)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
+
+// preload concurrently loads every *.csv rate sheet in dir into r via
+// Router.LoadFiles, so routesvc can start already serving a warm trie
+// instead of waiting for a client to stream one in over LoadRoutes.
+func preload(r *router.Router, dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.csv"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no *.csv files found in %s", dir)
+	}
+
+	rows, err := r.LoadFiles(paths)
+	if err != nil {
+		return err
+	}
+	log.Printf("preloaded %d rows from %d files in %s", rows, len(paths), dir)
+	return nil
+}
@@ -0,0 +1,223 @@
+// ==================================================================================
+// File: trie.go
+//
+// Desc: A compressed radix trie keyed by digit runes, used to store per-carrier
+//       route costs by number prefix. Compared to a flat map[string]string, the
+//       trie shares common prefixes between entries instead of storing each one
+//       in full, and a lookup walks the query once instead of probing every
+//       possible prefix length. Each node can carry a cost per carrier, so the
+//       cheapest vendor for a prefix can be picked at lookup time.
+//
+// Copyright © 2019 Edwin Cloud. All rights reserved.
+// ==================================================================================
+package trie
+
+// ----------------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------------
+import "sort"
+
+// ----------------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------------
+
+// node is a single node in the trie. edge is the compressed path segment leading
+// to this node from its parent, and children are keyed by the first byte of
+// their own edge so a lookup only ever has to check one candidate per level.
+type node struct {
+	edge     string
+	costs    map[string]float64 // carrier ID -> cost, nil until a carrier is inserted here
+	children map[byte]*node
+}
+
+// CarrierCost is a single carrier's cost for a prefix, as returned by
+// Trie.AllCarriers.
+type CarrierCost struct {
+	Carrier string
+	Cost    float64
+}
+
+// Trie is a compressed radix trie mapping number prefixes to the cheapest
+// known cost per carrier. The zero value is not usable, use New.
+type Trie struct {
+	root     *node
+	size     int
+	maxDepth int
+}
+
+// ----------------------------------------------------------------------------------
+// Exported Functions
+// ----------------------------------------------------------------------------------
+
+// New returns an initialized, empty Trie ready for Insert/CheapestCarrier.
+func New() *Trie {
+	return &Trie{root: newNode("")}
+}
+
+// ----------------------------------------------------------------------------------
+// Exported Methods
+// ----------------------------------------------------------------------------------
+
+// Insert adds cost for carrier at prefix into the trie. If carrier already
+// has a cost at prefix, the minimum of the existing and new cost is kept.
+func (t *Trie) Insert(prefix, carrier string, cost float64) {
+	isNew, depth := t.root.insert(prefix, carrier, cost, 0)
+	if isNew {
+		t.size++
+	}
+	if depth > t.maxDepth {
+		t.maxDepth = depth
+	}
+}
+
+// CheapestCarrier returns the carrier with the lowest cost at the longest
+// matching prefix of number, along with that cost. ok is false if no prefix
+// of number has been inserted.
+func (t *Trie) CheapestCarrier(number string) (carrier string, cost float64, ok bool) {
+	n := t.deepestMatch(number)
+	if n == nil {
+		return "", 0, false
+	}
+
+	first := true
+	for c, v := range n.costs {
+		if first || v < cost || (v == cost && c < carrier) {
+			carrier, cost, first = c, v, false
+		}
+	}
+	return carrier, cost, true
+}
+
+// AllCarriers returns every carrier with a cost at the longest matching
+// prefix of number, sorted cheapest first, for auditing.
+func (t *Trie) AllCarriers(number string) []CarrierCost {
+	n := t.deepestMatch(number)
+	if n == nil {
+		return nil
+	}
+
+	result := make([]CarrierCost, 0, len(n.costs))
+	for carrier, cost := range n.costs {
+		result = append(result, CarrierCost{Carrier: carrier, Cost: cost})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Cost != result[j].Cost {
+			return result[i].Cost < result[j].Cost
+		}
+		return result[i].Carrier < result[j].Carrier
+	})
+	return result
+}
+
+// Len returns the number of distinct prefixes currently stored in the trie.
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Depth returns the number of edges on the longest root-to-leaf path ever
+// reached in the trie, useful for reporting ingest progress on deeply
+// nested rate sheets. It is tracked incrementally by Insert rather than
+// recomputed, so it stays cheap to call while a large ingest is in flight.
+func (t *Trie) Depth() int {
+	return t.maxDepth
+}
+
+// ----------------------------------------------------------------------------------
+// Unexported Functions/Methods
+// ----------------------------------------------------------------------------------
+
+// newNode returns an empty node reached via edge.
+func newNode(edge string) *node {
+	return &node{edge: edge, children: make(map[byte]*node)}
+}
+
+// setCost keeps the minimum of carrier's current cost at n, if any, and cost.
+// It reports whether n held no costs for any carrier before this call.
+func (n *node) setCost(carrier string, cost float64) (wasEmpty bool) {
+	wasEmpty = len(n.costs) == 0
+	if n.costs == nil {
+		n.costs = make(map[string]float64)
+	}
+	if existing, ok := n.costs[carrier]; !ok || cost < existing {
+		n.costs[carrier] = cost
+	}
+	return wasEmpty
+}
+
+// insert places carrier's cost at the node reached by walking suffix from n,
+// splitting edges as needed. depth is n's own depth (edges from the root).
+// It reports whether a brand new prefix (one with no costs for any carrier)
+// was created, and the depth of the node the cost was ultimately set on.
+func (n *node) insert(suffix, carrier string, cost float64, depth int) (isNew bool, finalDepth int) {
+	if suffix == "" {
+		return n.setCost(carrier, cost), depth
+	}
+
+	child, ok := n.children[suffix[0]]
+	if !ok {
+		leaf := newNode(suffix)
+		leaf.setCost(carrier, cost)
+		n.children[suffix[0]] = leaf
+		return true, depth + 1
+	}
+
+	i := commonPrefixLen(child.edge, suffix)
+	switch {
+	case i == len(child.edge) && i == len(suffix):
+		return child.setCost(carrier, cost), depth + 1
+	case i == len(child.edge):
+		return child.insert(suffix[i:], carrier, cost, depth+1)
+	default:
+		// edges diverge partway through, split child's edge at i
+		mid := newNode(child.edge[:i])
+		child.edge = child.edge[i:]
+		mid.children[child.edge[0]] = child
+		n.children[suffix[0]] = mid
+		if i == len(suffix) {
+			mid.setCost(carrier, cost)
+			return true, depth + 1
+		}
+		leaf := newNode(suffix[i:])
+		leaf.setCost(carrier, cost)
+		mid.children[suffix[i]] = leaf
+		return true, depth + 2
+	}
+}
+
+// deepestMatch returns the deepest node along number's path that has a cost
+// for at least one carrier, or nil if no prefix of number has been inserted.
+func (t *Trie) deepestMatch(number string) *node {
+	var last *node
+	if len(t.root.costs) > 0 {
+		last = t.root
+	}
+
+	n := t.root
+	remaining := number
+	for remaining != "" {
+		child, ok := n.children[remaining[0]]
+		if !ok || len(child.edge) > len(remaining) || child.edge != remaining[:len(child.edge)] {
+			break
+		}
+		remaining = remaining[len(child.edge):]
+		n = child
+		if len(n.costs) > 0 {
+			last = n
+		}
+	}
+
+	return last
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b string) int {
+	max := len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+	i := 0
+	for i < max && a[i] == b[i] {
+		i++
+	}
+	return i
+}
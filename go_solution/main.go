@@ -1,10 +1,9 @@
 // ==================================================================================
 // File: main.go
 //
-// Desc: Call Routing project solution file in Go. This is the main solution as a 
-//       longer startup time is an acceptable tradeoff for near constant time 
-//       lookups. In a realistic scenario, the server would stay running anyways so 
-//       this tradoff would be negligible.
+// Desc: Call Routing project solution file in Go. This is now a thin gRPC client
+//       of the Router service served by cmd/routesvc; it owns no route-cost
+//       state itself so the TUI and any other client always see the same data.
 //
 // Copyright © 2019 Edwin Cloud. All rights reserved.
 // ==================================================================================
@@ -14,6 +13,8 @@ package main
 // Imports
 // ----------------------------------------------------------------------------------
 import (
+	"context"
+	"flag"
 	"os"
 	"strings"
 	format "github.com/labstack/gommon/color"
@@ -22,33 +23,57 @@ import (
 	"encoding/csv"
 	"io"
 	"io/ioutil"
+	"path/filepath"
+	"runtime"
 	"strconv"
 	"errors"
+	"sync"
 	"time"
+	routerv1 "github.com/edwintcloud/Call-Routing-Project/go_solution/api/v1"
+	"github.com/edwintcloud/Call-Routing-Project/go_solution/pkg/router"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // ----------------------------------------------------------------------------------
 // Global Variables
 // ----------------------------------------------------------------------------------
 var (
-	print     = format.Println
-	costs = make(map[string]string)
+	print  = format.Println
+	client routerv1.RouterClient
 )
 
+// routeChunkSize bounds how many rows are batched into a single RouteChunk
+// message while streaming a route-cost file to routesvc.
+const routeChunkSize = 500
+
 // ----------------------------------------------------------------------------------
 // Global Functions
 // ----------------------------------------------------------------------------------
 
 // main is the main entry point of the program.
 func main() {
+	addr := flag.String("addr", "localhost:50051", "address of the routesvc Router gRPC service")
+	flag.Parse()
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		print(format.Red(fmt.Sprintf("failed to connect to routesvc at %s: %v", *addr, err)))
+		os.Exit(1)
+	}
+	defer conn.Close()
+	client = routerv1.NewRouterClient(conn)
+
     for {
 		print(format.Underline("\nWelcome to the CallRoutes API!"))
-		print(format.Cyan(fmt.Sprintf("\n%d route costs currently loaded in memory.\n", len(costs))))
-		print(format.Green("1.) Load File into Memory"))
-		print(format.Green("2.) Lookup cost for a number"))
-		print(format.Green("3.) Lookup costs for all numbers in a file"))
-		print(format.Green("4.) Write resulting costs for all numbers in a file to a file"))
-		print(format.Red("5.) Exit"), "\n")
+		print(format.Cyan(fmt.Sprintf("\n%d route costs currently loaded in memory.\n", numLoaded())))
+		print(format.Green("1.) Load Files into Memory"))
+		print(format.Green("2.) Lookup cheapest carrier for a number"))
+		print(format.Green("3.) Show all carrier costs for a number"))
+		print(format.Green("4.) Lookup costs for all numbers in a file"))
+		print(format.Green("5.) Write resulting costs for all numbers in a file to a file"))
+		print(format.Red("6.) Exit"), "\n")
 		print("Please make a selection:")
 		choice := getInput()
 		switch choice {
@@ -56,14 +81,18 @@ func main() {
 			loadRouteCosts()
 		case "2":
 			print("\n", format.Cyan("Enter a number with the prefix:"))
-			prefix := getInput()
-			result := getCost(prefix)
-			print("\n", format.Magenta("Cost:"),format.Bold(format.Green(fmt.Sprintf("%s : %s", prefix, result))))
+			number := getInput()
+			carrier, cost := getCheapestCarrier(number)
+			print("\n", format.Magenta("Cost:"),format.Bold(format.Green(fmt.Sprintf("%s : %s (%s)", number, cost, carrier))))
 		case "3":
-			getAllCosts(false) // do not persist to disk
+			print("\n", format.Cyan("Enter a number with the prefix:"))
+			number := getInput()
+			printAllCarriers(number)
 		case "4":
-			getAllCosts(true) // persist to disk
+			getAllCosts(false) // do not persist to disk
 		case "5":
+			getAllCosts(true) // persist to disk
+		case "6":
 			return
 		default:
 			print(format.Magenta("\nInvalid input!"))
@@ -124,79 +153,190 @@ func listFiles(filter string) (string, error) {
 	return files[index-1].Name(), nil
 }
 
-// loadRouteCosts loads route costs from a selected route-costs
-// data file into the global variable costs type map[string]string.
+// matchingFilePaths returns the full ../data path of every file whose name
+// contains filter, used by loadRouteCosts to pick up every vendor rate
+// sheet in one pass instead of a single selected file.
+func matchingFilePaths(filter string) ([]string, error) {
+	allFiles, err := ioutil.ReadDir("../data")
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, file := range allFiles {
+		if strings.Contains(file.Name(), filter) {
+			paths = append(paths, filepath.Join("../data", file.Name()))
+		}
+	}
+	return paths, nil
+}
+
+// numLoaded asks routesvc for a single status snapshot and returns the
+// number of route costs currently loaded in its trie.
+func numLoaded() int64 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Status(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0
+	}
+	status, err := stream.Recv()
+	if err != nil {
+		return 0
+	}
+	return status.GetRowsIngested()
+}
+
+// loadRouteCosts streams every route-cost file in ../data into routesvc
+// over a single LoadRoutes client stream, reading the files concurrently
+// with a worker pool bounded by GOMAXPROCS. Each file is tagged with its
+// own carrier ID so real call routing's per-carrier comparison has
+// separate rate sheets to compare.
 func loadRouteCosts() {
 
-	// list available route-costs files, wait for a selection,
-	// ensure selection is valid, and get file name
-	fileName, err := listFiles("route")
+	// find every route-costs file to load, one per carrier
+	paths, err := matchingFilePaths("route")
 	if err != nil {
 		print("\n", format.Red(err.Error()))
 		return
 	}
+	if len(paths) == 0 {
+		print("\n", format.Red("no route-cost files found in ../data"))
+		return
+	}
+
+	print(format.Underline("\nLoading route-cost files:"), "\n")
+	for _, path := range paths {
+		print(format.Green(router.CarrierIDFromPath(path)))
+	}
 
 	// start timer
 	start := time.Now()
 
-	// open selected route-costs file and defer it to close 
-	// when this function returns
-	file, err := os.Open("../data/"+fileName)
+	// open a single LoadRoutes stream to routesvc, shared by every worker,
+	// on a cancelable context so a worker error can tear the stream down
+	// instead of leaking it and stranding the server-side handler in Recv()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.LoadRoutes(ctx)
 	if err != nil {
 		print("\n", format.Red(err.Error()))
 		return
 	}
+
+	// sendMu guards concurrent Send calls on the shared stream, which is
+	// not itself safe for concurrent use
+	var sendMu sync.Mutex
+	send := func(rows []*routerv1.RouteRow) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(&routerv1.RouteChunk{Rows: rows})
+	}
+
+	// read every file concurrently, bounded by GOMAXPROCS workers
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	errs := make(chan error, len(paths))
+	var wg sync.WaitGroup
+	for _, path := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if err := streamRouteFile(path, send); err != nil {
+				errs <- err
+			}
+		}(path)
+	}
+	wg.Wait()
+	close(errs)
+	if err := <-errs; err != nil {
+		cancel()
+		print("\n", format.Red(err.Error()))
+		return
+	}
+
+	status, err := stream.CloseAndRecv()
+	if err != nil {
+		print("\n", format.Red(err.Error()))
+		return
+	}
+
+	// print runtime for function
+	print(format.Yellow(fmt.Sprintf("\nLoaded %d rows in %v.", status.GetRowsLoaded(), time.Since(start))))
+}
+
+// streamRouteFile reads path's `prefix,cost` rows and hands them to send in
+// batches of routeChunkSize, tagging every row with the carrier ID derived
+// from path's file name.
+func streamRouteFile(path string, send func([]*routerv1.RouteRow) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
 	defer file.Close()
 
-	// create csv reader for file
+	carrier := router.CarrierIDFromPath(path)
 	reader := csv.NewReader(file)
+	rows := make([]*routerv1.RouteRow, 0, routeChunkSize)
 
-	// loop through all file lines
 	for {
-
-		// read row (file line)
 		row, err := reader.Read()
 		if err == io.EOF {
-			// once we hit the end-of-file, break from loop
 			break
 		} else if err != nil {
-			print("\n", format.Red(err.Error()))
-			return
+			return err
 		}
 
-		// if current row is in costs map and its cost is less than 
-		// current cost in costs map or if current row is not in 
-		// costs map
-		if v, ok := costs[row[0]]; (ok && v > row[1]) || !ok {
-			// insert or update current row into costs map
-			costs[row[0]] = row[1]
+		rows = append(rows, &routerv1.RouteRow{Prefix: row[0], Cost: row[1], Carrier: carrier})
+		if len(rows) == routeChunkSize {
+			if err := send(rows); err != nil {
+				return err
+			}
+			rows = rows[:0]
 		}
 	}
-
-	// print runtime for function
-	print(format.Yellow(fmt.Sprintf("\nCompleted in %v.", time.Since(start))))
+	if len(rows) == 0 {
+		return nil
+	}
+	return send(rows)
 }
 
-// getCost gets the cost for a given prefix by searching the costs map
-// for the longest matching prefix.
-func getCost(prefix string) string {
-
-	// set result equal to 0 by default
-	result := "0"
-
-	// find longest matching prefix
-	for i := len(prefix); i >= 0; i-- {
-		if v, ok := costs[prefix[:i]]; ok {
-			result = v
-		}
+// getCheapestCarrier gets the cheapest carrier and its cost for a given
+// number from routesvc.
+func getCheapestCarrier(number string) (carrier, cost string) {
+	resp, err := client.Lookup(context.Background(), &routerv1.NumberRequest{Number: number})
+	if err != nil {
+		print("\n", format.Red(err.Error()))
+		return "", "0"
 	}
+	return resp.GetCarrier(), resp.GetCost()
+}
 
-	// return result
-	return result
+// printAllCarriers prints every carrier's cost for number, cheapest first,
+// for auditing which vendor won and by how much.
+func printAllCarriers(number string) {
+	resp, err := client.Lookup(context.Background(), &routerv1.NumberRequest{Number: number})
+	if err != nil {
+		print("\n", format.Red(err.Error()))
+		return
+	}
+	if len(resp.GetCarriers()) == 0 {
+		print("\n", format.Magenta(fmt.Sprintf("No carriers found for %s.", number)))
+		return
+	}
+	for _, c := range resp.GetCarriers() {
+		print("\n", format.Magenta(c.GetCarrier()+":"), format.Bold(format.Green(c.GetCost())))
+	}
 }
 
 // getAllCosts prints all costs for a selected phone-numbers file to stdout
-// if persist is false, otherwise the costs are written to a file in /results
+// if persist is false, otherwise the costs are written to a file in
+// /results. Numbers are pipelined to routesvc over a single LookupStream
+// connection. When persisting, the caller can choose a narrow
+// number,cheapest_carrier,cost file or a wide file with one column per
+// carrier.
 func getAllCosts(persist bool) {
 
 	// list available phone-numbers files, wait for a selection,
@@ -207,10 +347,17 @@ func getAllCosts(persist bool) {
 		return
 	}
 
+	// ask for the output format if persisting to disk
+	wide := false
+	if persist {
+		print("\n", format.Cyan("Write one column per carrier? (y/N):"))
+		wide = strings.EqualFold(getInput(), "y")
+	}
+
 	// start timer
 	start := time.Now()
 
-	// open selected phone-numbers file and defer it to close 
+	// open selected phone-numbers file and defer it to close
 	// when this function returns
 	file, err := os.Open("../data/"+fileName)
 	if err != nil {
@@ -222,57 +369,136 @@ func getAllCosts(persist bool) {
 	// create csv reader for file
 	reader := csv.NewReader(file)
 
-	// create resultFile variable and create the file if 
-	// persist argument is true, defer file to close when
-	// this function returns
-	var resultFile *os.File
-	if persist {
-		err = os.Mkdir("results", os.ModePerm)
-		resultFile, err = os.Create("./results/"+ fileName)
-		if err != nil {
-			print("\n", format.Red(err.Error()))
-			return
-		}
+	// open a LookupStream connection to routesvc
+	stream, err := client.LookupStream(context.Background())
+	if err != nil {
+		print("\n", format.Red(err.Error()))
+		return
 	}
-	defer resultFile.Close()
 
-	// loop through all file lines
-	for {
+	// sendErr carries any error that occurs while sending requests on
+	// the goroutine below
+	sendErr := make(chan error, 1)
+
+	// send every number on the stream from a separate goroutine so
+	// sends and receives pipeline instead of round-tripping per number
+	go func() {
+		defer close(sendErr)
+		for {
+			row, err := reader.Read()
+			if err == io.EOF {
+				sendErr <- stream.CloseSend()
+				return
+			} else if err != nil {
+				sendErr <- err
+				return
+			}
+			if err := stream.Send(&routerv1.NumberRequest{Number: row[0]}); err != nil {
+				sendErr <- err
+				return
+			}
+		}
+	}()
 
-		// read row (file line)
-		row, err := reader.Read()
+	// collect every response; the wide format needs the full set before
+	// its header row (every carrier seen) can be written
+	var responses []*routerv1.CostResponse
+	for {
+		resp, err := stream.Recv()
 		if err == io.EOF {
-			// once we hit the end-of-file, break from loop
 			break
 		} else if err != nil {
 			print("\n", format.Red(err.Error()))
 			return
 		}
 
-		// get cost for current row's prefix
-		cost := getCost(row[0])
+		if !persist {
+			print("\n", format.Magenta("Cost:"),format.Bold(format.Green(fmt.Sprintf("%s : %s (%s)", resp.GetNumber(), resp.GetCost(), resp.GetCarrier()))))
+			continue
+		}
+		responses = append(responses, resp)
+	}
 
-		// if persist argument is true, append to result file
-		if persist {
-			_, err := resultFile.WriteString(fmt.Sprintf("%s,%s\n",row[0], cost))
-			if err != nil {
-				print("\n", format.Red(err.Error()))
-				return
-			}
-		// otherwise, print the result to stdout
-		} else {
-			print("\n", format.Magenta("Cost:"),format.Bold(format.Green(fmt.Sprintf("%s : %s",row[0], cost))))
-		}	
-		
+	if err := <-sendErr; err != nil {
+		print("\n", format.Red(err.Error()))
+		return
 	}
 
-	// if persist argument is true, sync result file to disk and
-	// print success message
+	// write the result file and print success message
 	if persist {
+		if err := os.Mkdir("results", os.ModePerm); err != nil && !os.IsExist(err) {
+			print("\n", format.Red(err.Error()))
+			return
+		}
+		resultFile, err := os.Create("./results/" + fileName)
+		if err != nil {
+			print("\n", format.Red(err.Error()))
+			return
+		}
+		defer resultFile.Close()
+
+		if wide {
+			err = writeWideResults(resultFile, responses)
+		} else {
+			err = writeNarrowResults(resultFile, responses)
+		}
+		if err != nil {
+			print("\n", format.Red(err.Error()))
+			return
+		}
+
 		resultFile.Sync()
 		print(format.Magenta(fmt.Sprintf("\nResult file /results/%s created!", fileName)))
 	}
 
 	// print runtime for function
 	print(format.Yellow(fmt.Sprintf("\nCompleted in %v.", time.Since(start))))
-}
\ No newline at end of file
+}
+
+// writeNarrowResults writes one number,cheapest_carrier,cost row per
+// response.
+func writeNarrowResults(w io.Writer, responses []*routerv1.CostResponse) error {
+	for _, resp := range responses {
+		_, err := fmt.Fprintf(w, "%s,%s,%s\n", resp.GetNumber(), resp.GetCarrier(), resp.GetCost())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeWideResults writes a header of every carrier seen across responses
+// followed by one row per number with that carrier's cost, or a blank
+// field if the carrier didn't quote that number.
+func writeWideResults(w io.Writer, responses []*routerv1.CostResponse) error {
+	seen := map[string]bool{}
+	var carriers []string
+	for _, resp := range responses {
+		for _, c := range resp.GetCarriers() {
+			if !seen[c.GetCarrier()] {
+				seen[c.GetCarrier()] = true
+				carriers = append(carriers, c.GetCarrier())
+			}
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "number,%s\n", strings.Join(carriers, ",")); err != nil {
+		return err
+	}
+
+	for _, resp := range responses {
+		costByCarrier := map[string]string{}
+		for _, c := range resp.GetCarriers() {
+			costByCarrier[c.GetCarrier()] = c.GetCost()
+		}
+
+		cols := make([]string, len(carriers))
+		for i, carrier := range carriers {
+			cols[i] = costByCarrier[carrier]
+		}
+		if _, err := fmt.Fprintf(w, "%s,%s\n", resp.GetNumber(), strings.Join(cols, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+// ==================================================================================
+// File: router.go
+//
+// Desc: Router is the shared library behind both the interactive TUI and the
+//       routesvc gRPC server. It owns the in-memory per-carrier route-cost
+//       trie so both surfaces load and look up costs through the exact same
+//       code path. Real call routing compares several vendor rate sheets and
+//       picks the cheapest carrier per prefix, so costs are tracked per
+//       carrier rather than as a single number.
+//
+// Copyright © 2019 Edwin Cloud. All rights reserved.
+// ==================================================================================
+package router
+
+// ----------------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------------
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edwintcloud/Call-Routing-Project/go_solution/internal/trie"
+)
+
+// ----------------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------------
+
+// Router looks up the cheapest carrier's cost for a number against its
+// in-memory per-carrier costs trie.
+type Router struct {
+	mu    sync.Mutex
+	costs *trie.Trie
+}
+
+// CarrierCost is a single carrier's cost for a prefix, as returned by
+// GetAllCarriers.
+type CarrierCost = trie.CarrierCost
+
+// ----------------------------------------------------------------------------------
+// Exported Functions
+// ----------------------------------------------------------------------------------
+
+// New returns a Router with an empty costs trie, ready for Load/Insert.
+func New() *Router {
+	return &Router{costs: trie.New()}
+}
+
+// CarrierIDFromPath derives a carrier ID from a rate sheet's file name by
+// dropping its directory and extension, e.g. "../data/vendorA-rates.csv"
+// becomes "vendorA-rates".
+func CarrierIDFromPath(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// ----------------------------------------------------------------------------------
+// Exported Methods
+// ----------------------------------------------------------------------------------
+
+// Insert adds a single prefix/cost pair for carrier into the router,
+// keeping the minimum cost when carrier already has one at prefix.
+func (r *Router) Insert(carrier, prefix string, cost float64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.costs.Insert(prefix, carrier, cost)
+}
+
+// Load reads a route-cost CSV of `prefix,cost` rows from reader and inserts
+// every row under carrier into the costs trie, keeping the minimum cost for
+// duplicate prefixes. It returns the number of rows read.
+func (r *Router) Load(carrier string, reader io.Reader) (int, error) {
+	rows := 0
+	csvReader := csv.NewReader(reader)
+	for {
+		row, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return rows, err
+		}
+		cost, err := strconv.ParseFloat(row[1], 64)
+		if err != nil {
+			return rows, err
+		}
+		r.Insert(carrier, row[0], cost)
+		rows++
+	}
+	return rows, nil
+}
+
+// LoadFiles loads one or more route-cost CSV files concurrently, bounded by
+// GOMAXPROCS workers, treating each file as a distinct carrier named by
+// CarrierIDFromPath. Costs merge into the shared trie as each file finishes
+// reading, keeping the minimum cost per prefix+carrier pair. It returns the
+// total number of rows read across all files, and the first error
+// encountered, if any.
+func (r *Router) LoadFiles(paths []string) (int, error) {
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	results := make(chan fileLoadResult, len(paths))
+	var wg sync.WaitGroup
+
+	for _, path := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results <- r.loadFile(path)
+		}(path)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var total int
+	var firstErr error
+	for res := range results {
+		if res.err != nil && firstErr == nil {
+			firstErr = res.err
+		}
+		total += res.rows
+	}
+	return total, firstErr
+}
+
+// GetCheapestCarrier returns the cheapest carrier and cost for the longest
+// matching prefix of number, or ok == false if no prefix of number has been
+// loaded.
+func (r *Router) GetCheapestCarrier(number string) (carrier string, cost float64, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.costs.CheapestCarrier(number)
+}
+
+// GetAllCarriers returns every carrier with a cost at the longest matching
+// prefix of number, sorted cheapest first, for auditing.
+func (r *Router) GetAllCarriers(number string) []CarrierCost {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.costs.AllCarriers(number)
+}
+
+// Len returns the number of distinct prefixes currently loaded.
+func (r *Router) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.costs.Len()
+}
+
+// Depth returns the longest root-to-leaf edge count in the costs trie.
+func (r *Router) Depth() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.costs.Depth()
+}
+
+// ----------------------------------------------------------------------------------
+// Unexported Types/Methods
+// ----------------------------------------------------------------------------------
+
+// fileLoadResult is one LoadFiles worker's outcome for a single file.
+type fileLoadResult struct {
+	rows int
+	err  error
+}
+
+// loadFile opens path and loads it under the carrier derived from its name.
+func (r *Router) loadFile(path string) fileLoadResult {
+	file, err := os.Open(path)
+	if err != nil {
+		return fileLoadResult{err: err}
+	}
+	defer file.Close()
+
+	rows, err := r.Load(CarrierIDFromPath(path), file)
+	return fileLoadResult{rows: rows, err: err}
+}
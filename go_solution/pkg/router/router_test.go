@@ -0,0 +1,94 @@
+// ==================================================================================
+// File: router_test.go
+//
+// Desc: Unit tests for Router's CSV loading, concurrent multi-file ingestion,
+//       and per-carrier cost lookup.
+//
+// Copyright © 2019 Edwin Cloud. All rights reserved.
+// ==================================================================================
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoadAndCheapestCarrier ensures Load inserts every row of a route-cost
+// CSV under the given carrier and GetCheapestCarrier finds the longest
+// matching prefix afterward.
+func TestLoadAndCheapestCarrier(t *testing.T) {
+	r := New()
+
+	csv := "1,0.10\n44,0.05\n44207,0.01\n"
+	rows, err := r.Load("vendorA", strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("Load() rows = %d, want %d", rows, 3)
+	}
+	if got := r.Len(); got != 3 {
+		t.Errorf("Len() = %d, want %d", got, 3)
+	}
+
+	if carrier, cost, ok := r.GetCheapestCarrier("442075551234"); !ok || carrier != "vendorA" || cost != 0.01 {
+		t.Errorf(`GetCheapestCarrier("442075551234") = (%q, %v, %v), want (%q, %v, true)`, carrier, cost, ok, "vendorA", 0.01)
+	}
+	if _, _, ok := r.GetCheapestCarrier("9999999"); ok {
+		t.Errorf(`GetCheapestCarrier("9999999") ok = true, want false`)
+	}
+}
+
+// TestLoadFilesMergesOverlappingPrefixesAcrossCarriers ensures concurrently
+// loading several rate sheets merges overlapping prefix/carrier pairs,
+// keeping the minimum cost, and that GetAllCarriers/GetCheapestCarrier see
+// every carrier afterward.
+func TestLoadFilesMergesOverlappingPrefixesAcrossCarriers(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"vendorA.csv": "44207,0.05\n44207,0.03\n", // duplicate row within one carrier
+		"vendorB.csv": "44207,0.02\n",
+		"vendorC.csv": "44207,0.09\n1,0.20\n",
+	}
+	paths := make([]string, 0, len(files))
+	for name, contents := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	r := New()
+	rows, err := r.LoadFiles(paths)
+	if err != nil {
+		t.Fatalf("LoadFiles() error = %v", err)
+	}
+	if rows != 5 {
+		t.Errorf("LoadFiles() rows = %d, want %d", rows, 5)
+	}
+
+	carrier, cost, ok := r.GetCheapestCarrier("442075551234")
+	if !ok || carrier != "vendorB" || cost != 0.02 {
+		t.Errorf("GetCheapestCarrier() = (%q, %v, %v), want (%q, %v, true)", carrier, cost, ok, "vendorB", 0.02)
+	}
+
+	all := r.GetAllCarriers("442075551234")
+	if len(all) != 3 {
+		t.Fatalf("GetAllCarriers() = %v, want 3 carriers", all)
+	}
+	if all[0].Carrier != "vendorB" || all[1].Carrier != "vendorA" || all[1].Cost != 0.03 {
+		t.Errorf("GetAllCarriers() = %v, want vendorB cheapest then vendorA at 0.03", all)
+	}
+}
+
+// TestCarrierIDFromPath ensures the carrier ID derived from a rate sheet
+// path drops its directory and extension.
+func TestCarrierIDFromPath(t *testing.T) {
+	if got := CarrierIDFromPath("../data/vendorA-rates.csv"); got != "vendorA-rates" {
+		t.Errorf("CarrierIDFromPath() = %q, want %q", got, "vendorA-rates")
+	}
+}
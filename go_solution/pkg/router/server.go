@@ -0,0 +1,154 @@
+// ==================================================================================
+// File: server.go
+//
+// Desc: Server adapts a Router onto the generated routerv1.RouterServer
+// interface, so cmd/routesvc only has to wire up a net.Listener and a
+// *grpc.Server around it.
+//
+// Copyright © 2019 Edwin Cloud. All rights reserved.
+// ==================================================================================
+package router
+
+// ----------------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------------
+import (
+	"context"
+	"io"
+	"runtime"
+	"strconv"
+	"time"
+
+	routerv1 "github.com/edwintcloud/Call-Routing-Project/go_solution/api/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ----------------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------------
+
+// Server implements routerv1.RouterServer on top of a Router.
+type Server struct {
+	routerv1.UnimplementedRouterServer
+	router *Router
+}
+
+// ----------------------------------------------------------------------------------
+// Exported Functions
+// ----------------------------------------------------------------------------------
+
+// NewServer returns a Server backed by router.
+func NewServer(router *Router) *Server {
+	return &Server{router: router}
+}
+
+// ----------------------------------------------------------------------------------
+// Exported Methods
+// ----------------------------------------------------------------------------------
+
+// Lookup returns the cheapest carrier's cost for a single number, along
+// with every carrier's cost for auditing.
+func (s *Server) Lookup(ctx context.Context, req *routerv1.NumberRequest) (*routerv1.CostResponse, error) {
+	return s.costResponse(req.GetNumber()), nil
+}
+
+// LookupStream pipelines many number lookups over a single connection.
+func (s *Server) LookupStream(stream routerv1.Router_LookupStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := stream.Send(s.costResponse(req.GetNumber())); err != nil {
+			return err
+		}
+	}
+}
+
+// LoadRoutes streams a route-cost CSV into the server's trie without the
+// server ever reading it from disk.
+func (s *Server) LoadRoutes(stream routerv1.Router_LoadRoutesServer) error {
+	var rows int64
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&routerv1.LoadStatus{RowsLoaded: rows})
+		} else if err != nil {
+			return err
+		}
+		for _, row := range chunk.GetRows() {
+			cost, err := strconv.ParseFloat(row.GetCost(), 64)
+			if err != nil {
+				return err
+			}
+			s.router.Insert(row.GetCarrier(), row.GetPrefix(), cost)
+			rows++
+		}
+	}
+}
+
+// Status periodically emits ingest progress while a LoadRoutes call is in
+// flight.
+func (s *Server) Status(_ *emptypb.Empty, stream routerv1.Router_StatusServer) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	send := func() error {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		return stream.Send(&routerv1.StatusResponse{
+			RowsIngested: int64(s.router.Len()),
+			MemoryBytes:  int64(mem.Alloc),
+			TrieDepth:    int32(s.router.Depth()),
+		})
+	}
+
+	// send an initial snapshot immediately so callers don't block on the
+	// first tick just to learn the current state
+	if err := send(); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------------
+// Unexported Methods
+// ----------------------------------------------------------------------------------
+
+// costResponse builds a CostResponse for number, carrying its cheapest
+// carrier and every carrier's cost for auditing.
+func (s *Server) costResponse(number string) *routerv1.CostResponse {
+	carrier, cost, ok := s.router.GetCheapestCarrier(number)
+	resp := &routerv1.CostResponse{Number: number, Cost: "0"}
+	if !ok {
+		return resp
+	}
+	resp.Cost = formatCost(cost)
+	resp.Carrier = carrier
+
+	for _, c := range s.router.GetAllCarriers(number) {
+		resp.Carriers = append(resp.Carriers, &routerv1.CarrierCost{
+			Carrier: c.Carrier,
+			Cost:    formatCost(c.Cost),
+		})
+	}
+	return resp
+}
+
+// formatCost renders cost using the shortest decimal representation that
+// round-trips back to the same float64.
+func formatCost(cost float64) string {
+	return strconv.FormatFloat(cost, 'f', -1, 64)
+}